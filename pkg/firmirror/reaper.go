@@ -0,0 +1,116 @@
+package firmirror
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy controls which firmware a Reaper considers stale within a
+// vendor/model group (the key's directory portion).
+type RetentionPolicy struct {
+	// KeepLatest always keeps the N most recently modified objects in a
+	// group. Zero means no keep-latest floor.
+	KeepLatest int
+
+	// MaxAge deletes objects older than this duration. Zero disables
+	// age-based deletion.
+	MaxAge time.Duration
+}
+
+// Reaper prunes mirrored firmware that's fallen outside a RetentionPolicy,
+// closing the operational gap where firmware otherwise accumulates forever
+// with no API surface to prune it.
+type Reaper struct {
+	storage Storage
+	policy  RetentionPolicy
+}
+
+// NewReaper creates a Reaper that applies policy to objects in storage.
+func NewReaper(storage Storage, policy RetentionPolicy) *Reaper {
+	return &Reaper{storage: storage, policy: policy}
+}
+
+// Run lists everything under prefix, groups by vendor/model (the key's
+// directory portion), and deletes objects that fall outside the retention
+// policy within each group. It returns the keys it deleted.
+func (r *Reaper) Run(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := r.storage.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects under %q: %w", prefix, err)
+	}
+
+	groups := make(map[string][]string)
+	for _, key := range keys {
+		group := groupOf(key)
+		groups[group] = append(groups[group], key)
+	}
+
+	var deleted []string
+	for _, group := range groups {
+		victims, err := r.reapGroup(ctx, group)
+		deleted = append(deleted, victims...)
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+type statted struct {
+	key  string
+	info ObjectInfo
+}
+
+// reapGroup statts every key in a group, ranks by modtime, and deletes
+// whatever the policy marks as stale, newest first by keep-latest.
+func (r *Reaper) reapGroup(ctx context.Context, keys []string) ([]string, error) {
+	entries := make([]statted, 0, len(keys))
+	for _, key := range keys {
+		info, err := r.storage.Stat(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %q: %w", key, err)
+		}
+		entries = append(entries, statted{key: key, info: info})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].info.ModTime.After(entries[j].info.ModTime)
+	})
+
+	now := time.Now()
+	var deleted []string
+
+	for i, e := range entries {
+		if r.policy.KeepLatest > 0 && i < r.policy.KeepLatest {
+			continue
+		}
+
+		shouldDelete := r.policy.KeepLatest > 0
+		if r.policy.MaxAge > 0 {
+			shouldDelete = now.Sub(e.info.ModTime) > r.policy.MaxAge
+		}
+		if !shouldDelete {
+			continue
+		}
+
+		if err := r.storage.Delete(ctx, e.key); err != nil {
+			return deleted, fmt.Errorf("failed to delete %q: %w", e.key, err)
+		}
+		deleted = append(deleted, e.key)
+	}
+
+	return deleted, nil
+}
+
+// groupOf returns the directory portion of key (everything before the last
+// "/"), used to scope retention to a vendor/model.
+func groupOf(key string) string {
+	if idx := strings.LastIndex(key, "/"); idx >= 0 {
+		return key[:idx]
+	}
+	return ""
+}
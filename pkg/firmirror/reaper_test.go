@@ -0,0 +1,80 @@
+package firmirror
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReaperKeepLatestOnly(t *testing.T) {
+	storage, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	ctx := context.Background()
+
+	writeAt := func(key string, age time.Duration) {
+		if err := storage.Write(ctx, key, bytes.NewReader([]byte("fw"))); err != nil {
+			t.Fatalf("Write %q: %v", key, err)
+		}
+		mtime := time.Now().Add(-age)
+		if err := chtimes(t, storage, key, mtime); err != nil {
+			t.Fatalf("chtimes %q: %v", key, err)
+		}
+	}
+
+	writeAt("vendor1-model1-v1.bin", 3*time.Hour)
+	writeAt("vendor1-model1-v2.bin", 2*time.Hour)
+	writeAt("vendor1-model1-v3.bin", 1*time.Hour)
+
+	r := NewReaper(storage, RetentionPolicy{KeepLatest: 2})
+	deleted, err := r.Run(ctx, "")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "vendor1-model1-v1.bin" {
+		t.Fatalf("expected only the oldest to be deleted, got %v", deleted)
+	}
+}
+
+func TestReaperMaxAgeBeyondKeepLatestFloor(t *testing.T) {
+	storage, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	ctx := context.Background()
+
+	writeAt := func(key string, age time.Duration) {
+		if err := storage.Write(ctx, key, bytes.NewReader([]byte("fw"))); err != nil {
+			t.Fatalf("Write %q: %v", key, err)
+		}
+		mtime := time.Now().Add(-age)
+		if err := chtimes(t, storage, key, mtime); err != nil {
+			t.Fatalf("chtimes %q: %v", key, err)
+		}
+	}
+
+	writeAt("vendor1-model1-v1.bin", 48*time.Hour)
+	writeAt("vendor1-model1-v2.bin", 1*time.Hour)
+
+	r := NewReaper(storage, RetentionPolicy{KeepLatest: 1, MaxAge: 24 * time.Hour})
+	deleted, err := r.Run(ctx, "")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "vendor1-model1-v1.bin" {
+		t.Fatalf("expected only the stale object past the keep-latest floor to be deleted, got %v", deleted)
+	}
+}
+
+// chtimes backdates the mtime of a key written through LocalStorage, since
+// Write always stamps the current time.
+func chtimes(t *testing.T, storage *LocalStorage, key string, mtime time.Time) error {
+	t.Helper()
+	return os.Chtimes(filepath.Join(storage.basePath, key), mtime, mtime)
+}
@@ -2,7 +2,9 @@ package firmirror
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"time"
 )
 
 // Interface for different storage backends
@@ -15,5 +17,75 @@ type Storage interface {
 
 	// Exists checks if a key exists
 	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete removes the object for the given key
+	Delete(ctx context.Context, key string) error
+
+	// Stat returns metadata for the given key without fetching its contents
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+
+	// List returns all keys with the given prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// ObjectInfo describes metadata about a stored object.
+type ObjectInfo struct {
+	Size        int64
+	ModTime     time.Time
+	ETag        string
+	ContentType string
+}
+
+// ChecksumMismatchError indicates the bytes read back for a key didn't match
+// the checksum recorded when it was written, signalling corruption in the
+// backend or in transit. Callers can retry the read or quarantine the key.
+type ChecksumMismatchError struct {
+	Key      string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %q: expected %s, got %s", e.Key, e.Expected, e.Actual)
+}
+
+// presignConfig holds the options accumulated from PresignOption values.
+type presignConfig struct {
+	contentDisposition   string
+	sseCustomerAlgorithm string
+	sseCustomerKey       string
+	sseCustomerKeyMD5    string
+}
+
+// PresignOption configures an optional PresignRead call.
+type PresignOption func(*presignConfig)
+
+// WithPresignContentDisposition sets the Content-Disposition header returned
+// when the presigned URL is fetched.
+func WithPresignContentDisposition(contentDisposition string) PresignOption {
+	return func(c *presignConfig) { c.contentDisposition = contentDisposition }
+}
+
+// WithPresignSSECustomerKey sets SSE-C headers required to read an object
+// encrypted with customer-provided keys.
+func WithPresignSSECustomerKey(algorithm, key, keyMD5 string) PresignOption {
+	return func(c *presignConfig) {
+		c.sseCustomerAlgorithm = algorithm
+		c.sseCustomerKey = key
+		c.sseCustomerKeyMD5 = keyMD5
+	}
+}
+
+// PresignedReader is an optional capability satisfied by Storage backends
+// that can hand callers a time-limited URL instead of streaming data
+// through the mirror service. Firmware consumers (PXE/BMC/clients) can pull
+// the image directly from the backend rather than proxying hundreds of MB
+// through this service.
+type PresignedReader interface {
+	// PresignRead returns a URL valid for ttl that lets a caller fetch key
+	// directly from the backend. Backends that can't support this (e.g.
+	// LocalStorage) return an error so callers can type-assert and fall
+	// back to Read.
+	PresignRead(ctx context.Context, key string, ttl time.Duration, opts ...PresignOption) (string, error)
 }
 
@@ -0,0 +1,348 @@
+package firmirror
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheStorage is a cache-through Storage that keeps a "hot" backend (e.g.
+// LocalStorage) in front of a "cold" backend (e.g. S3Storage). This is the
+// expected shape for edge mirror nodes that keep recently-requested firmware
+// local in front of a central S3 mirror.
+type CacheStorage struct {
+	hot  Storage
+	cold Storage
+
+	maxBytes        int64
+	janitorInterval time.Duration
+	evictBatch      int
+
+	mu       sync.Mutex
+	lru      *list.List // front = most recently used
+	entries  map[string]*list.Element
+	curBytes int64
+
+	sf singleflight.Group
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// CacheStorageOption configures a CacheStorage.
+type CacheStorageOption func(*CacheStorage)
+
+// WithMaxBytes sets the maximum number of bytes kept in the hot tier before
+// the janitor starts evicting least-recently-used entries. Zero (the
+// default) disables eviction.
+func WithMaxBytes(n int64) CacheStorageOption {
+	return func(c *CacheStorage) { c.maxBytes = n }
+}
+
+// WithJanitorInterval sets how often the background janitor checks for
+// entries to evict. Defaults to one minute.
+func WithJanitorInterval(d time.Duration) CacheStorageOption {
+	return func(c *CacheStorage) { c.janitorInterval = d }
+}
+
+// NewCacheStorage creates a CacheStorage wrapping hot and cold backends,
+// seeds its LRU accounting from whatever is already in the hot tier (so a
+// process restart doesn't forget about files left on disk from a prior run),
+// and starts its background janitor goroutine. Call Close to stop the
+// janitor.
+func NewCacheStorage(ctx context.Context, hot, cold Storage, opts ...CacheStorageOption) (*CacheStorage, error) {
+	c := &CacheStorage{
+		hot:             hot,
+		cold:            cold,
+		lru:             list.New(),
+		entries:         make(map[string]*list.Element),
+		janitorInterval: time.Minute,
+		evictBatch:      8,
+		stopCh:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.seedFromHot(ctx); err != nil {
+		return nil, fmt.Errorf("failed to seed cache from hot tier: %w", err)
+	}
+
+	go c.janitor()
+
+	return c, nil
+}
+
+// seedFromHot reconciles LRU accounting against whatever is already on disk
+// in the hot tier, so a restarted process doesn't let usage grow unbounded
+// past maxBytes just because curBytes came back up at zero. Entries are
+// recorded oldest-to-newest by ModTime so the resulting LRU order
+// approximates actual recency.
+func (c *CacheStorage) seedFromHot(ctx context.Context) error {
+	keys, err := c.hot.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list hot tier: %w", err)
+	}
+
+	type existing struct {
+		key  string
+		info ObjectInfo
+	}
+	entries := make([]existing, 0, len(keys))
+	for _, key := range keys {
+		info, err := c.hot.Stat(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to stat %q in hot tier: %w", key, err)
+		}
+		entries = append(entries, existing{key: key, info: info})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].info.ModTime.Before(entries[j].info.ModTime)
+	})
+
+	for _, e := range entries {
+		c.recordWrite(e.key, e.info.Size)
+	}
+
+	return nil
+}
+
+// Close stops the background janitor goroutine.
+func (c *CacheStorage) Close() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+}
+
+// countingReader wraps an io.Reader to track how many bytes have passed
+// through it, so CacheStorage can maintain its own LRU size accounting
+// without depending on a Stat call against the hot backend.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// Write streams data to cold storage and, through a tee, to the hot cache at
+// the same time, so firmware is only read from the caller once. The hot copy
+// is kept only if the cold write succeeds.
+func (c *CacheStorage) Write(ctx context.Context, key string, data io.Reader) error {
+	pr, pw := io.Pipe()
+	counter := &countingReader{r: pr}
+
+	hotErrCh := make(chan error, 1)
+	go func() {
+		err := c.hot.Write(ctx, key, counter)
+		// Drain whatever the hot write didn't consume itself: if it returned
+		// early (e.g. os.Create failing on a bad path), nothing else reads
+		// from pr, and the cold-side TeeReader's pw.Write below would block
+		// forever waiting for a reader that's never coming.
+		_, _ = io.Copy(io.Discard, counter)
+		hotErrCh <- err
+	}()
+
+	if err := c.cold.Write(ctx, key, io.TeeReader(data, pw)); err != nil {
+		pw.CloseWithError(err)
+		<-hotErrCh
+		// Cold storage is the source of truth: drop whatever reached the hot
+		// tier so Read doesn't serve an object that cold storage lacks.
+		_ = c.hot.Delete(ctx, key)
+		c.forget(key)
+		return fmt.Errorf("failed to write %q to cold storage: %w", key, err)
+	}
+	pw.Close()
+
+	if err := <-hotErrCh; err != nil {
+		// The hot tier is best-effort: cold storage is the source of truth,
+		// so a population failure here doesn't fail the write.
+		return nil
+	}
+
+	c.recordWrite(key, counter.n)
+	return nil
+}
+
+// Read serves from the hot tier when present. On a hot miss it fetches from
+// cold, populates hot, and serves from hot; concurrent misses for the same
+// key are coalesced into a single cold fetch.
+func (c *CacheStorage) Read(ctx context.Context, key string) (io.ReadCloser, error) {
+	if rc, err := c.hot.Read(ctx, key); err == nil {
+		c.touch(key)
+		return rc, nil
+	}
+
+	_, err, _ := c.sf.Do(key, func() (interface{}, error) {
+		return nil, c.populateHot(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := c.hot.Read(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q after populating cache: %w", key, err)
+	}
+	c.touch(key)
+	return rc, nil
+}
+
+// populateHot copies key from cold into the hot tier and records its size
+// for LRU accounting.
+func (c *CacheStorage) populateHot(ctx context.Context, key string) error {
+	rc, err := c.cold.Read(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to read %q from cold storage: %w", key, err)
+	}
+	defer rc.Close()
+
+	counter := &countingReader{r: rc}
+	if err := c.hot.Write(ctx, key, counter); err != nil {
+		return fmt.Errorf("failed to populate hot cache for %q: %w", key, err)
+	}
+
+	c.recordWrite(key, counter.n)
+	return nil
+}
+
+// Exists checks hot then cold.
+func (c *CacheStorage) Exists(ctx context.Context, key string) (bool, error) {
+	ok, err := c.hot.Exists(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to check hot tier for %q: %w", key, err)
+	}
+	if ok {
+		return true, nil
+	}
+	return c.cold.Exists(ctx, key)
+}
+
+// Delete removes key from cold storage (the source of truth) and best-effort
+// from the hot tier, forgetting its LRU accounting either way.
+func (c *CacheStorage) Delete(ctx context.Context, key string) error {
+	if err := c.cold.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete %q from cold storage: %w", key, err)
+	}
+	_ = c.hot.Delete(ctx, key)
+	c.forget(key)
+	return nil
+}
+
+// Stat checks hot then cold.
+func (c *CacheStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if info, err := c.hot.Stat(ctx, key); err == nil {
+		return info, nil
+	}
+	return c.cold.Stat(ctx, key)
+}
+
+// List delegates to cold storage: the hot tier only holds a partial,
+// size-bounded subset of keys, so it isn't authoritative for listing.
+func (c *CacheStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	return c.cold.List(ctx, prefix)
+}
+
+func (c *CacheStorage) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		c.curBytes -= entry.size
+	}
+}
+
+func (c *CacheStorage) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+	}
+}
+
+func (c *CacheStorage) recordWrite(key string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		c.curBytes += size - entry.size
+		entry.size = size
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	el := c.lru.PushFront(&cacheEntry{key: key, size: size})
+	c.entries[key] = el
+	c.curBytes += size
+}
+
+// janitor periodically evicts least-recently-used entries from the hot tier
+// until usage is back under maxBytes.
+func (c *CacheStorage) janitor() {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.evict(context.Background())
+		}
+	}
+}
+
+// evict removes least-recently-used entries, up to evictBatch at a time, and
+// deletes them from the hot tier with bounded concurrency.
+func (c *CacheStorage) evict(ctx context.Context) {
+	var victims []string
+
+	c.mu.Lock()
+	for c.curBytes > c.maxBytes && len(victims) < c.evictBatch {
+		el := c.lru.Back()
+		if el == nil {
+			break
+		}
+		entry := el.Value.(*cacheEntry)
+		c.lru.Remove(el)
+		delete(c.entries, entry.key)
+		c.curBytes -= entry.size
+		victims = append(victims, entry.key)
+	}
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, key := range victims {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			c.evictOne(ctx, key)
+		}(key)
+	}
+	wg.Wait()
+}
+
+// evictOne removes key from the hot tier.
+func (c *CacheStorage) evictOne(ctx context.Context, key string) {
+	_ = c.hot.Delete(ctx, key)
+}
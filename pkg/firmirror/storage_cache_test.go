@@ -0,0 +1,214 @@
+package firmirror
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memStorage is a minimal in-memory Storage used to exercise CacheStorage
+// without touching the filesystem or S3.
+type memStorage struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	writeErr error // if set, Write returns this error without draining data
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{objects: make(map[string][]byte)}
+}
+
+func (m *memStorage) Write(ctx context.Context, key string, data io.Reader) error {
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.objects[key] = b
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *memStorage) Read(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	b, ok := m.objects[key]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%q not found", key)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m *memStorage) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	_, ok := m.objects[key]
+	m.mu.Unlock()
+	return ok, nil
+}
+
+func (m *memStorage) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.objects, key)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *memStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	m.mu.Lock()
+	b, ok := m.objects[key]
+	m.mu.Unlock()
+	if !ok {
+		return ObjectInfo{}, fmt.Errorf("%q not found", key)
+	}
+	return ObjectInfo{Size: int64(len(b)), ModTime: time.Now()}, nil
+}
+
+func (m *memStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for k := range m.objects {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func newTestCacheStorage(t *testing.T, hot, cold Storage, opts ...CacheStorageOption) *CacheStorage {
+	t.Helper()
+	c, err := NewCacheStorage(context.Background(), hot, cold, opts...)
+	if err != nil {
+		t.Fatalf("NewCacheStorage: %v", err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestCacheStorageWriteReadPopulatesHot(t *testing.T) {
+	hot, cold := newMemStorage(), newMemStorage()
+	c := newTestCacheStorage(t, hot, cold)
+
+	if err := c.Write(context.Background(), "fw.bin", bytes.NewReader([]byte("payload"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if _, ok := hot.objects["fw.bin"]; !ok {
+		t.Fatalf("expected hot tier to be populated by Write")
+	}
+	if _, ok := cold.objects["fw.bin"]; !ok {
+		t.Fatalf("expected cold tier to be populated by Write")
+	}
+
+	rc, err := c.Read(context.Background(), "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+	got, _ := io.ReadAll(rc)
+	if string(got) != "payload" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestCacheStorageReadMissPopulatesHotFromCold(t *testing.T) {
+	hot, cold := newMemStorage(), newMemStorage()
+	cold.objects["fw.bin"] = []byte("from-cold")
+
+	c := newTestCacheStorage(t, hot, cold)
+
+	rc, err := c.Read(context.Background(), "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(got) != "from-cold" {
+		t.Fatalf("got %q", got)
+	}
+
+	if _, ok := hot.objects["fw.bin"]; !ok {
+		t.Fatalf("expected hot tier to be populated after a cold read")
+	}
+}
+
+// TestCacheStorageWriteDoesNotDeadlockOnHotWriteError is a regression test:
+// the hot tier can fail (e.g. disk full, bad path) without ever reading its
+// input reader. Write must not hang waiting for that reader to drain.
+func TestCacheStorageWriteDoesNotDeadlockOnHotWriteError(t *testing.T) {
+	hot := newMemStorage()
+	hot.writeErr = fmt.Errorf("disk full")
+	cold := newMemStorage()
+
+	c := newTestCacheStorage(t, hot, cold)
+
+	done := make(chan error, 1)
+	go func() {
+		// Large enough that a blocked pipe write would hang rather than
+		// complete by coincidence.
+		done <- c.Write(context.Background(), "fw.bin", bytes.NewReader(make([]byte, 1<<20)))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Write deadlocked when the hot tier failed without draining its input")
+	}
+
+	if _, ok := cold.objects["fw.bin"]; !ok {
+		t.Fatalf("expected cold tier to still be populated despite the hot tier failing")
+	}
+}
+
+func TestCacheStorageEvictsLeastRecentlyUsed(t *testing.T) {
+	hot, cold := newMemStorage(), newMemStorage()
+	c := newTestCacheStorage(t, hot, cold, WithMaxBytes(10))
+
+	ctx := context.Background()
+	if err := c.Write(ctx, "a", bytes.NewReader(make([]byte, 6))); err != nil {
+		t.Fatalf("Write a: %v", err)
+	}
+	if err := c.Write(ctx, "b", bytes.NewReader(make([]byte, 6))); err != nil {
+		t.Fatalf("Write b: %v", err)
+	}
+
+	c.evict(ctx)
+
+	if _, ok := hot.objects["a"]; ok {
+		t.Fatalf("expected least-recently-used entry %q to be evicted from hot tier", "a")
+	}
+	if _, ok := hot.objects["b"]; !ok {
+		t.Fatalf("expected most recently written entry %q to remain in hot tier", "b")
+	}
+}
+
+// TestCacheStorageSeedsLRUFromExistingHotFiles is a regression test: without
+// seeding, curBytes starts at zero on every restart even though the hot tier
+// already holds files from a prior run, letting usage grow unbounded past
+// maxBytes.
+func TestCacheStorageSeedsLRUFromExistingHotFiles(t *testing.T) {
+	hot, cold := newMemStorage(), newMemStorage()
+	hot.objects["existing.bin"] = make([]byte, 7)
+
+	c := newTestCacheStorage(t, hot, cold, WithMaxBytes(100))
+
+	c.mu.Lock()
+	size := c.curBytes
+	_, tracked := c.entries["existing.bin"]
+	c.mu.Unlock()
+
+	if !tracked {
+		t.Fatalf("expected pre-existing hot file to be tracked in the LRU after NewCacheStorage")
+	}
+	if size != 7 {
+		t.Fatalf("expected curBytes to account for the pre-existing file, got %d", size)
+	}
+}
@@ -2,51 +2,133 @@ package firmirror
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
+	"io/fs"
+	"mime"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
+// sha256Suffix names the sidecar file LocalStorage writes alongside each
+// object to hold its checksum.
+const sha256Suffix = ".sha256"
+
 // LocalStorage implements Storage interface for local filesystem
 type LocalStorage struct {
-	basePath string
+	basePath       string
+	verifyChecksum bool
+}
+
+// LocalStorageOption configures optional behavior of a LocalStorage instance.
+type LocalStorageOption func(*LocalStorage)
+
+// WithLocalVerifyChecksum controls whether Read verifies the sidecar
+// checksum written alongside each object. Defaults to true; callers that
+// already validate a signature at a higher layer can disable it.
+func WithLocalVerifyChecksum(verify bool) LocalStorageOption {
+	return func(s *LocalStorage) { s.verifyChecksum = verify }
 }
 
 // NewLocalStorage creates a new LocalStorage instance
-func NewLocalStorage(basePath string) (*LocalStorage, error) {
+func NewLocalStorage(basePath string, opts ...LocalStorageOption) (*LocalStorage, error) {
 	// Create base directory if it doesn't exist
 	if err := os.MkdirAll(basePath, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create base path: %w", err)
 	}
-	return &LocalStorage{basePath: basePath}, nil
+
+	s := &LocalStorage{basePath: basePath, verifyChecksum: true}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
-// Write stores data with the given key to the filesystem
+// Write stores data with the given key to the filesystem and persists a
+// sidecar .sha256 file alongside it.
 func (s *LocalStorage) Write(ctx context.Context, key string, data io.Reader) error {
 	fullPath := filepath.Join(s.basePath, key)
 
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
 	file, err := os.Create(fullPath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
 	defer file.Close()
 
-	if _, err := io.Copy(file, data); err != nil {
+	hasher := sha256.New()
+	if _, err := io.Copy(file, io.TeeReader(data, hasher)); err != nil {
 		return fmt.Errorf("failed to write data: %w", err)
 	}
 
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if err := os.WriteFile(fullPath+sha256Suffix, []byte(checksum), 0644); err != nil {
+		return fmt.Errorf("failed to write checksum sidecar: %w", err)
+	}
+
 	return nil
 }
 
-// Read retrieves data for the given key from the filesystem
+// checksumVerifyingReadCloser hashes bytes as they're read and compares
+// against an expected checksum once the underlying reader hits EOF.
+type checksumVerifyingReadCloser struct {
+	rc       io.ReadCloser
+	hasher   hash.Hash
+	expected string
+	key      string
+}
+
+func (r *checksumVerifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		r.hasher.Write(p[:n])
+	}
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hasher.Sum(nil)); actual != r.expected {
+			return n, &ChecksumMismatchError{Key: r.key, Expected: r.expected, Actual: actual}
+		}
+	}
+	return n, err
+}
+
+func (r *checksumVerifyingReadCloser) Close() error {
+	return r.rc.Close()
+}
+
+// Read retrieves data for the given key from the filesystem, verifying it
+// against the sidecar .sha256 file unless verification is disabled or no
+// sidecar exists (e.g. the key was written before checksums were introduced).
 func (s *LocalStorage) Read(ctx context.Context, key string) (io.ReadCloser, error) {
 	fullPath := filepath.Join(s.basePath, key)
 	file, err := os.Open(fullPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
-	return file, nil
+
+	if !s.verifyChecksum {
+		return file, nil
+	}
+
+	expected, err := os.ReadFile(fullPath + sha256Suffix)
+	if err != nil {
+		return file, nil
+	}
+
+	return &checksumVerifyingReadCloser{
+		rc:       file,
+		hasher:   sha256.New(),
+		expected: strings.TrimSpace(string(expected)),
+		key:      key,
+	}, nil
 }
 
 // Exists checks if a key exists in the filesystem
@@ -61,3 +143,76 @@ func (s *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
 	}
 	return true, nil
 }
+
+// Delete removes the file and checksum sidecar for the given key from the
+// filesystem.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	fullPath := filepath.Join(s.basePath, key)
+	if err := os.Remove(fullPath); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	_ = os.Remove(fullPath + sha256Suffix)
+	return nil
+}
+
+// PresignRead satisfies PresignedReader but always fails: local disk has no
+// notion of a presigned URL. Callers should type-assert for PresignedReader
+// and fall back to Read when it's not supported.
+func (s *LocalStorage) PresignRead(ctx context.Context, key string, ttl time.Duration, opts ...PresignOption) (string, error) {
+	return "", fmt.Errorf("local storage does not support presigned URLs")
+}
+
+// Stat returns size/modtime/content-type metadata for the given key.
+func (s *LocalStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	fullPath := filepath.Join(s.basePath, key)
+	fi, err := os.Stat(fullPath)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return ObjectInfo{
+		Size:        fi.Size(),
+		ModTime:     fi.ModTime(),
+		ContentType: mime.TypeByExtension(filepath.Ext(key)),
+	}, nil
+}
+
+// List returns all keys with the given prefix under the base path. prefix is
+// matched as a string prefix over keys, the same semantics as S3Storage.List,
+// not as a literal directory component: "vendor1-model1" matches the key
+// "vendor1-model1-v1.bin" just as it would against an S3 bucket.
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	// Walking the prefix's parent directory means we still see siblings like
+	// "vendor1-model1-v1.bin" that don't themselves have "vendor1-model1" as
+	// a path component.
+	walkRoot := filepath.Join(s.basePath, filepath.Dir(prefix))
+
+	var keys []string
+	err := filepath.WalkDir(walkRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, sha256Suffix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.basePath, path)
+		if err != nil {
+			return err
+		}
+		relSlash := filepath.ToSlash(rel)
+		if !strings.HasPrefix(relSlash, prefix) {
+			return nil
+		}
+		keys = append(keys, relSlash)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return keys, nil
+}
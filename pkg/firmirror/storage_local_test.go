@@ -0,0 +1,126 @@
+package firmirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorageWriteReadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	want := []byte("firmware-bytes")
+	if err := s.Write(context.Background(), "vendor/model/fw.bin", bytes.NewReader(want)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rc, err := s.Read(context.Background(), "vendor/model/fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestLocalStorageReadDetectsChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	if err := s.Write(context.Background(), "fw.bin", bytes.NewReader([]byte("original"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Corrupt the object after the fact without touching its sidecar, as if
+	// the bytes on disk had bit-rotted.
+	if err := os.WriteFile(filepath.Join(dir, "fw.bin"), []byte("corrupted"), 0644); err != nil {
+		t.Fatalf("corrupt file: %v", err)
+	}
+
+	rc, err := s.Read(context.Background(), "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	_, err = io.ReadAll(rc)
+	var mismatch *ChecksumMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected ChecksumMismatchError, got %v", err)
+	}
+}
+
+func TestLocalStorageListMatchesStringPrefixNotDirectory(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	ctx := context.Background()
+
+	// Flat, non-nested keys: "vendor1-model1" is a string prefix of these
+	// but not a directory component of any of them.
+	for _, key := range []string{"vendor1-model1-v1.bin", "vendor1-model1-v2.bin", "vendor2-model1-v1.bin"} {
+		if err := s.Write(ctx, key, bytes.NewReader([]byte("fw"))); err != nil {
+			t.Fatalf("Write %q: %v", key, err)
+		}
+	}
+
+	got, err := s.List(ctx, "vendor1-model1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	want := map[string]bool{"vendor1-model1-v1.bin": true, "vendor1-model1-v2.bin": true}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want keys matching %v", got, want)
+	}
+	for _, k := range got {
+		if !want[k] {
+			t.Fatalf("unexpected key %q in List result %v", k, got)
+		}
+	}
+}
+
+func TestLocalStorageReadWithoutSidecarSkipsVerification(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "legacy.bin"), []byte("pre-checksum"), 0644); err != nil {
+		t.Fatalf("write legacy file: %v", err)
+	}
+
+	rc, err := s.Read(context.Background(), "legacy.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "pre-checksum" {
+		t.Fatalf("got %q", got)
+	}
+}
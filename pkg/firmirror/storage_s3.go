@@ -1,44 +1,277 @@
 package firmirror
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // S3Storage implements Storage interface for AWS S3 or S3-compatible storage
 type S3Storage struct {
-	client     *s3.Client
-	uploader   *manager.Uploader
-	downloader *manager.Downloader
-	bucket     string
-	prefix     string // optional prefix for all keys
+	client        *s3.Client
+	uploader      *manager.Uploader
+	downloader    *manager.Downloader
+	presignClient *s3.PresignClient
+	bucket        string
+	prefix        string // optional prefix for all keys
+
+	// Applied to every PutObjectInput on Write.
+	sse                  types.ServerSideEncryption
+	sseKMSKeyID          string
+	sseCustomerAlgorithm string
+	sseCustomerKey       string
+	sseCustomerKeyMD5    string
+	storageClass         types.StorageClass
+	acl                  types.ObjectCannedACL
+	tagging              string
+	verifyChecksum       bool
+}
+
+// s3Config holds the options accumulated from Option values passed to NewS3Storage.
+type s3Config struct {
+	partSize    int64
+	concurrency int
+
+	sse                  types.ServerSideEncryption
+	sseKMSKeyID          string
+	sseCustomerAlgorithm string
+	sseCustomerKey       string
+	sseCustomerKeyMD5    string
+	storageClass         types.StorageClass
+	acl                  types.ObjectCannedACL
+	tags                 map[string]string
+	credentials          *CredentialsConfig
+	verifyChecksum       bool
+}
+
+// CredentialsConfig selects how S3Storage authenticates to AWS. Exactly one
+// of Static, Anonymous, AssumeRole, WebIdentity or EC2InstanceRole should be
+// set; if none are, the SDK's default credential chain is used (environment,
+// shared config, EC2/ECS instance role). Useful in multi-tenant mirror
+// setups where each vendor's bucket is written under a distinct IAM role.
+type CredentialsConfig struct {
+	// Static configures long-lived access/secret keys.
+	Static *StaticCredentials
+
+	// Anonymous disables signing entirely, for public mirror buckets.
+	Anonymous bool
+
+	// AssumeRole configures an stscreds.AssumeRoleProvider.
+	AssumeRole *AssumeRoleConfig
+
+	// WebIdentity configures the IRSA/web-identity provider used by
+	// Kubernetes service accounts.
+	WebIdentity *WebIdentityConfig
+
+	// EC2InstanceRole forces use of the EC2 instance metadata role provider
+	// rather than relying on it as the default chain's last resort.
+	EC2InstanceRole bool
+}
+
+// StaticCredentials is a long-lived access/secret key pair.
+type StaticCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AssumeRoleConfig configures assuming an IAM role via STS.
+type AssumeRoleConfig struct {
+	RoleARN     string
+	ExternalID  string
+	SessionName string
+}
+
+// WebIdentityConfig configures the web-identity/IRSA provider used by
+// Kubernetes service accounts. RoleARN and TokenFilePath are normally read
+// from the AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE environment
+// variables Kubernetes injects; only set this explicitly to override them.
+type WebIdentityConfig struct {
+	RoleARN       string
+	TokenFilePath string
+	SessionName   string
+}
+
+// assumeRoleOptions builds the stscreds.AssumeRoleProvider option func for
+// ar, applying ExternalID and SessionName only when set so the provider's
+// own zero-value defaults (e.g. a generated session name) still apply.
+// Extracted from NewS3Storage so it can be exercised against a fake STS
+// client without a real AssumeRole round trip.
+func assumeRoleOptions(ar *AssumeRoleConfig) func(*stscreds.AssumeRoleOptions) {
+	return func(o *stscreds.AssumeRoleOptions) {
+		if ar.ExternalID != "" {
+			o.ExternalID = aws.String(ar.ExternalID)
+		}
+		if ar.SessionName != "" {
+			o.RoleSessionName = ar.SessionName
+		}
+	}
+}
+
+// resolveWebIdentityConfig fills RoleARN/TokenFilePath from the
+// AWS_ROLE_ARN/AWS_WEB_IDENTITY_TOKEN_FILE environment variables when wi
+// leaves them unset, matching the default WebIdentityConfig documents.
+func resolveWebIdentityConfig(wi *WebIdentityConfig) (roleARN, tokenFilePath string) {
+	roleARN = wi.RoleARN
+	if roleARN == "" {
+		roleARN = os.Getenv("AWS_ROLE_ARN")
+	}
+	tokenFilePath = wi.TokenFilePath
+	if tokenFilePath == "" {
+		tokenFilePath = os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	}
+	return roleARN, tokenFilePath
+}
+
+// webIdentityOptions builds the stscreds.WebIdentityRoleProvider option func
+// for wi. Extracted from NewS3Storage so it can be exercised against a fake
+// STS client without a real AssumeRoleWithWebIdentity round trip.
+func webIdentityOptions(wi *WebIdentityConfig) func(*stscreds.WebIdentityRoleOptions) {
+	return func(o *stscreds.WebIdentityRoleOptions) {
+		if wi.SessionName != "" {
+			o.RoleSessionName = wi.SessionName
+		}
+	}
+}
+
+// WithCredentials selects how S3Storage authenticates to AWS. Without this
+// option, NewS3Storage falls back to the SDK's default credential chain.
+func WithCredentials(creds CredentialsConfig) Option {
+	return func(c *s3Config) { c.credentials = &creds }
+}
+
+// Option configures optional behavior of an S3Storage instance.
+type Option func(*s3Config)
+
+// WithPartSize sets the multipart upload part size in bytes. Downloads use
+// the SDK's default part size.
+func WithPartSize(size int64) Option {
+	return func(c *s3Config) { c.partSize = size }
+}
+
+// WithConcurrency sets the number of concurrent parts used for uploads.
+func WithConcurrency(n int) Option {
+	return func(c *s3Config) { c.concurrency = n }
 }
 
-func NewS3Storage(ctx context.Context, bucket, prefix, region, endpoint string) (*S3Storage, error) {
+// WithServerSideEncryption sets SSE-S3 or SSE-KMS encryption, applied to
+// every object written. kmsKeyID is ignored unless sse is
+// types.ServerSideEncryptionAwsKms.
+func WithServerSideEncryption(sse types.ServerSideEncryption, kmsKeyID string) Option {
+	return func(c *s3Config) {
+		c.sse = sse
+		c.sseKMSKeyID = kmsKeyID
+	}
+}
+
+// WithSSECustomerKey sets SSE-C encryption headers, applied to every object
+// written.
+func WithSSECustomerKey(algorithm, key, keyMD5 string) Option {
+	return func(c *s3Config) {
+		c.sseCustomerAlgorithm = algorithm
+		c.sseCustomerKey = key
+		c.sseCustomerKeyMD5 = keyMD5
+	}
+}
+
+// WithStorageClass sets the storage class (e.g. STANDARD_IA, GLACIER_IR,
+// DEEP_ARCHIVE) applied to every object written, letting an operator-defined
+// lifecycle push older firmware to cheaper tiers.
+func WithStorageClass(class types.StorageClass) Option {
+	return func(c *s3Config) { c.storageClass = class }
+}
+
+// WithACL sets the canned ACL applied to every object written.
+func WithACL(acl types.ObjectCannedACL) Option {
+	return func(c *s3Config) { c.acl = acl }
+}
+
+// WithVerifyChecksum controls whether Read verifies the streamed object
+// against the SHA256 checksum recorded on Write. Defaults to true; callers
+// pulling large objects that already validate a signature at a higher layer
+// can disable it.
+func WithVerifyChecksum(verify bool) Option {
+	return func(c *s3Config) { c.verifyChecksum = verify }
+}
+
+// WithTags sets object tags (e.g. vendor, model, version) applied to every
+// object written, enabling audits without a parallel metadata store.
+func WithTags(tags map[string]string) Option {
+	return func(c *s3Config) { c.tags = tags }
+}
+
+func NewS3Storage(ctx context.Context, bucket, prefix, region, endpoint string, opts ...Option) (*S3Storage, error) {
 	if bucket == "" {
 		return nil, fmt.Errorf("bucket name is required")
 	}
 
-	var opts []func(*config.LoadOptions) error
+	cfg := s3Config{
+		partSize:       manager.DefaultUploadPartSize,
+		concurrency:    manager.DefaultUploadConcurrency,
+		verifyChecksum: true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var loadOpts []func(*config.LoadOptions) error
 
 	if region != "" {
-		opts = append(opts, config.WithRegion(region))
+		loadOpts = append(loadOpts, config.WithRegion(region))
+	}
+
+	if cfg.credentials != nil {
+		switch {
+		case cfg.credentials.Static != nil:
+			sc := cfg.credentials.Static
+			loadOpts = append(loadOpts, config.WithCredentialsProvider(
+				credentials.NewStaticCredentialsProvider(sc.AccessKeyID, sc.SecretAccessKey, sc.SessionToken),
+			))
+		case cfg.credentials.Anonymous:
+			loadOpts = append(loadOpts, config.WithCredentialsProvider(aws.AnonymousCredentials{}))
+		case cfg.credentials.EC2InstanceRole:
+			loadOpts = append(loadOpts, config.WithCredentialsProvider(ec2rolecreds.New()))
+		}
 	}
 
-	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	awsCfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
+	if cfg.credentials != nil {
+		switch {
+		case cfg.credentials.AssumeRole != nil:
+			ar := cfg.credentials.AssumeRole
+			stsClient := sts.NewFromConfig(awsCfg)
+			provider := stscreds.NewAssumeRoleProvider(stsClient, ar.RoleARN, assumeRoleOptions(ar))
+			awsCfg.Credentials = aws.NewCredentialsCache(provider)
+		case cfg.credentials.WebIdentity != nil:
+			wi := cfg.credentials.WebIdentity
+			roleARN, tokenFilePath := resolveWebIdentityConfig(wi)
+			stsClient := sts.NewFromConfig(awsCfg)
+			provider := stscreds.NewWebIdentityRoleProvider(stsClient, roleARN, stscreds.IdentityTokenFile(tokenFilePath), webIdentityOptions(wi))
+			awsCfg.Credentials = aws.NewCredentialsCache(provider)
+		}
+	}
+
 	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
 		if endpoint != "" {
 			o.BaseEndpoint = aws.String(endpoint)
@@ -52,12 +285,36 @@ func NewS3Storage(ctx context.Context, bucket, prefix, region, endpoint string)
 		return nil, fmt.Errorf("failed to access bucket %s: %w", bucket, err)
 	}
 
+	uploader := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = cfg.partSize
+		u.Concurrency = cfg.concurrency
+	})
+
+	var tagging string
+	if len(cfg.tags) > 0 {
+		values := url.Values{}
+		for k, v := range cfg.tags {
+			values.Set(k, v)
+		}
+		tagging = values.Encode()
+	}
+
 	return &S3Storage{
-		client:     client,
-		uploader:   manager.NewUploader(client),
-		downloader: manager.NewDownloader(client),
-		bucket:     bucket,
-		prefix:     prefix,
+		client:               client,
+		uploader:             uploader,
+		downloader:           manager.NewDownloader(client),
+		presignClient:        s3.NewPresignClient(client),
+		bucket:               bucket,
+		prefix:               prefix,
+		sse:                  cfg.sse,
+		sseKMSKeyID:          cfg.sseKMSKeyID,
+		sseCustomerAlgorithm: cfg.sseCustomerAlgorithm,
+		sseCustomerKey:       cfg.sseCustomerKey,
+		sseCustomerKeyMD5:    cfg.sseCustomerKeyMD5,
+		storageClass:         cfg.storageClass,
+		acl:                  cfg.acl,
+		tagging:              tagging,
+		verifyChecksum:       cfg.verifyChecksum,
 	}, nil
 }
 
@@ -69,65 +326,280 @@ func (s *S3Storage) buildKey(key string) string {
 	return key
 }
 
-// Write stores data with the given key to S3
+// Write streams data to S3 using multipart upload, avoiding full in-memory
+// buffering of the firmware image. If data doesn't implement io.Seeker (some
+// S3-compatible backends require Content-Length, which the SDK can only
+// determine from a seekable body), it is spilled to a temp file first.
 func (s *S3Storage) Write(ctx context.Context, key string, data io.Reader) error {
 	fullKey := s.buildKey(key)
 
-	// Read data into buffer to determine size (needed for some S3-compatible services)
-	// This also allows us to retry in case of transient errors
-	buf, err := io.ReadAll(data)
+	body := data
+	if _, ok := data.(io.Seeker); !ok {
+		tmp, err := os.CreateTemp("", "firmirror-upload-*")
+		if err != nil {
+			return fmt.Errorf("failed to create spill file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if _, err := io.Copy(tmp, data); err != nil {
+			return fmt.Errorf("failed to spill data to temp file: %w", err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind spill file: %w", err)
+		}
+		body = tmp
+	}
+
+	// Hash the body ourselves rather than relying on PutObjectInput's
+	// ChecksumAlgorithm: for a multipart upload (the default path for
+	// anything over PartSize) that produces a composite, per-part checksum,
+	// not a SHA256 of the full object, which Read can't verify against.
+	// Hash in a separate pass and rewind rather than wrapping body in
+	// io.TeeReader: TeeReader would hide body's io.ReaderAt/io.Seeker
+	// behind a plain io.Reader, which defeats manager.Uploader's
+	// concurrent-part read fast path (see nextReader in the SDK's
+	// upload.go) and serializes the whole upload instead.
+	seeker := body.(io.Seeker)
+
+	// Hashing consumes body from wherever it's currently positioned, which
+	// isn't necessarily offset 0 for a caller-supplied reader. Capture that
+	// starting offset so the bytes we hash are the bytes we upload.
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to get body offset before hashing: %w", err)
+	}
+
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, body)
 	if err != nil {
-		return fmt.Errorf("failed to read data: %w", err)
+		return fmt.Errorf("failed to hash data: %w", err)
 	}
 
-	_, err = s.uploader.Upload(ctx, &s3.PutObjectInput{
+	// manager.Uploader's readerAtSeeker fast path reads an io.ReaderAt body
+	// by absolute offset starting at 0, ignoring body's current Seek
+	// position (see nextReader in the SDK's upload.go) - so for a body that
+	// implements io.ReaderAt, just seeking back to start isn't enough to
+	// make the uploaded bytes match the ones just hashed. Re-root body at
+	// start instead, so offset 0 in the uploader's view is the same byte we
+	// started hashing from.
+	if ra, ok := body.(io.ReaderAt); ok {
+		body = io.NewSectionReader(ra, start, n)
+	} else if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind body after hashing: %w", err)
+	}
+
+	input := &s3.PutObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(fullKey),
-		Body:   bytes.NewReader(buf),
-	})
-	if err != nil {
+		Body:   body,
+	}
+	s.applyWriteOptions(input)
+
+	if _, err := s.uploader.Upload(ctx, input); err != nil {
 		return fmt.Errorf("failed to upload to S3: %w", err)
 	}
 
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if err := s.writeChecksumSidecar(ctx, fullKey, checksum); err != nil {
+		return fmt.Errorf("failed to write checksum sidecar for %q: %w", key, err)
+	}
+
 	return nil
 }
 
-// Read retrieves data for the given key from S3
+// writeChecksumSidecar stores checksum in a small sidecar object at
+// fullKey+sha256Suffix, mirroring LocalStorage's on-disk sidecar file.
+// An earlier version stamped the checksum onto the object's own metadata
+// via a same-bucket self-copy, but CopyObject's single-request form caps
+// source objects at 5GiB, which broke exactly the multi-GB firmware images
+// Write's streaming upload exists to support. A plain PutObject has no such
+// cap. It still carries forward the same encryption, storage class, ACL and
+// tagging as the original object so the sidecar doesn't weaken the bucket's
+// security posture.
+func (s *S3Storage) writeChecksumSidecar(ctx context.Context, fullKey, checksum string) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fullKey + sha256Suffix),
+		Body:   strings.NewReader(checksum),
+	}
+	s.applyWriteOptions(input)
+
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+// applyWriteOptions sets the encryption, storage class, ACL and tagging
+// configured on S3Storage onto every PutObjectInput.
+func (s *S3Storage) applyWriteOptions(input *s3.PutObjectInput) {
+	if s.sse != "" {
+		input.ServerSideEncryption = s.sse
+		if s.sse == types.ServerSideEncryptionAwsKms && s.sseKMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(s.sseKMSKeyID)
+		}
+	}
+	if s.sseCustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(s.sseCustomerAlgorithm)
+		input.SSECustomerKey = aws.String(s.sseCustomerKey)
+		input.SSECustomerKeyMD5 = aws.String(s.sseCustomerKeyMD5)
+	}
+	if s.storageClass != "" {
+		input.StorageClass = s.storageClass
+	}
+	if s.acl != "" {
+		input.ACL = s.acl
+	}
+	if s.tagging != "" {
+		input.Tagging = aws.String(s.tagging)
+	}
+}
+
+// sequentialWriterAt adapts an io.Writer to the io.WriterAt required by
+// manager.Downloader. It's only safe when the downloader is forced to
+// Concurrency=1, which guarantees parts arrive in order with no overlapping
+// writes.
+type sequentialWriterAt struct {
+	w io.Writer
+}
+
+func (sw *sequentialWriterAt) WriteAt(p []byte, _ int64) (int, error) {
+	return sw.w.Write(p)
+}
+
+// Read streams data for the given key from S3 without buffering the whole
+// object in memory: the downloader drives an io.Pipe with Concurrency=1 so
+// parts are written to the pipe in order as they arrive. Unless
+// VerifyChecksum is disabled, the streamed bytes are hashed and compared
+// against the checksum recorded on Write.
 func (s *S3Storage) Read(ctx context.Context, key string) (io.ReadCloser, error) {
 	fullKey := s.buildKey(key)
 
-	// Download to buffer
-	buf := manager.NewWriteAtBuffer([]byte{})
-	_, err := s.downloader.Download(ctx, buf, &s3.GetObjectInput{
+	var expectedSHA256 string
+	if s.verifyChecksum {
+		var err error
+		expectedSHA256, err = s.readChecksumSidecar(ctx, fullKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checksum sidecar for %q: %w", key, err)
+		}
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		hasher := sha256.New()
+		target := io.Writer(pw)
+		if expectedSHA256 != "" {
+			target = io.MultiWriter(pw, hasher)
+		}
+
+		_, downloadErr := s.downloader.Download(ctx, &sequentialWriterAt{w: target}, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(fullKey),
+		}, func(d *manager.Downloader) {
+			d.Concurrency = 1
+		})
+
+		var err error
+		switch {
+		case downloadErr != nil:
+			err = fmt.Errorf("failed to download from S3: %w", downloadErr)
+		case expectedSHA256 != "":
+			if actual := hex.EncodeToString(hasher.Sum(nil)); actual != expectedSHA256 {
+				err = &ChecksumMismatchError{Key: key, Expected: expectedSHA256, Actual: actual}
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
+
+// readChecksumSidecar fetches the sidecar object written by
+// writeChecksumSidecar, returning "" without error if none exists (e.g. the
+// key was written before checksums were introduced), mirroring
+// LocalStorage.Read's sidecar fallback.
+func (s *S3Storage) readChecksumSidecar(ctx context.Context, fullKey string) (string, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
-		Key:    aws.String(fullKey),
+		Key:    aws.String(fullKey + sha256Suffix),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to download from S3: %w", err)
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
 	}
+	defer out.Body.Close()
 
-	// Return buffer as ReadCloser
-	return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
 }
 
 // Exists checks if a key exists in S3
 func (s *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.Stat(ctx, key)
+	if err == nil {
+		return true, nil
+	}
+
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Stat returns size/modtime/etag/content-type metadata for key without
+// downloading it.
+func (s *S3Storage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
 	fullKey := s.buildKey(key)
 
-	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(fullKey),
 	})
 	if err != nil {
-		// Check if it's a not found error
-		var notFound *types.NotFound
-		if errors.As(err, &notFound) {
-			return false, nil
-		}
-		return false, fmt.Errorf("failed to check object existence: %w", err)
+		return ObjectInfo{}, fmt.Errorf("failed to stat object %q: %w", key, err)
+	}
+
+	info := ObjectInfo{}
+	if out.ContentLength != nil {
+		info.Size = *out.ContentLength
+	}
+	if out.LastModified != nil {
+		info.ModTime = *out.LastModified
+	}
+	if out.ETag != nil {
+		info.ETag = strings.Trim(*out.ETag, `"`)
+	}
+	if out.ContentType != nil {
+		info.ContentType = *out.ContentType
 	}
 
-	return true, nil
+	return info, nil
+}
+
+// Delete removes the object and its checksum sidecar for the given key from S3.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	fullKey := s.buildKey(key)
+
+	if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fullKey),
+	}); err != nil {
+		return fmt.Errorf("failed to delete object %q: %w", key, err)
+	}
+
+	_, _ = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fullKey + sha256Suffix),
+	})
+
+	return nil
 }
 
 // List returns all keys with the given prefix (useful for debugging and management)
@@ -147,16 +619,47 @@ func (s *S3Storage) List(ctx context.Context, prefix string) ([]string, error) {
 		}
 
 		for _, obj := range page.Contents {
-			if obj.Key != nil {
-				// Remove the storage prefix from the returned keys
-				key := *obj.Key
-				if s.prefix != "" && len(key) > len(s.prefix)+1 {
-					key = key[len(s.prefix)+1:]
-				}
-				keys = append(keys, key)
+			if obj.Key == nil || strings.HasSuffix(*obj.Key, sha256Suffix) {
+				continue
+			}
+			// Remove the storage prefix from the returned keys
+			key := *obj.Key
+			if s.prefix != "" && len(key) > len(s.prefix)+1 {
+				key = key[len(s.prefix)+1:]
 			}
+			keys = append(keys, key)
 		}
 	}
 
 	return keys, nil
 }
+
+// PresignRead returns a URL valid for ttl that lets a caller fetch key
+// directly from S3, bypassing the mirror service for large transfers.
+func (s *S3Storage) PresignRead(ctx context.Context, key string, ttl time.Duration, opts ...PresignOption) (string, error) {
+	var cfg presignConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	fullKey := s.buildKey(key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(fullKey),
+	}
+	if cfg.contentDisposition != "" {
+		input.ResponseContentDisposition = aws.String(cfg.contentDisposition)
+	}
+	if cfg.sseCustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(cfg.sseCustomerAlgorithm)
+		input.SSECustomerKey = aws.String(cfg.sseCustomerKey)
+		input.SSECustomerKeyMD5 = aws.String(cfg.sseCustomerKeyMD5)
+	}
+
+	req, err := s.presignClient.PresignGetObject(ctx, input, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign read for %q: %w", key, err)
+	}
+
+	return req.URL, nil
+}
@@ -0,0 +1,172 @@
+package firmirror
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// fakeAssumeRoleClient is a stscreds.AssumeRoleAPIClient stand-in that
+// records the AssumeRole input it was called with and returns a canned
+// response, letting assumeRoleOptions be exercised without a real STS round
+// trip.
+type fakeAssumeRoleClient struct {
+	gotInput *sts.AssumeRoleInput
+}
+
+func (f *fakeAssumeRoleClient) AssumeRole(ctx context.Context, params *sts.AssumeRoleInput, optFns ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	f.gotInput = params
+	return &sts.AssumeRoleOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String("assumed-access-key"),
+			SecretAccessKey: aws.String("assumed-secret-key"),
+			SessionToken:    aws.String("assumed-session-token"),
+			Expiration:      aws.Time(time.Unix(0, 0)),
+		},
+	}, nil
+}
+
+func TestAssumeRoleOptionsAppliedToProvider(t *testing.T) {
+	fake := &fakeAssumeRoleClient{}
+	ar := &AssumeRoleConfig{
+		RoleARN:     "arn:aws:iam::123456789012:role/firmirror-writer",
+		ExternalID:  "ext-id",
+		SessionName: "firmirror-session",
+	}
+	provider := stscreds.NewAssumeRoleProvider(fake, ar.RoleARN, assumeRoleOptions(ar))
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+
+	if fake.gotInput == nil {
+		t.Fatal("AssumeRole was never called")
+	}
+	if got := aws.ToString(fake.gotInput.RoleArn); got != ar.RoleARN {
+		t.Errorf("RoleArn = %q, want %q", got, ar.RoleARN)
+	}
+	if got := aws.ToString(fake.gotInput.ExternalId); got != ar.ExternalID {
+		t.Errorf("ExternalId = %q, want %q", got, ar.ExternalID)
+	}
+	if got := aws.ToString(fake.gotInput.RoleSessionName); got != ar.SessionName {
+		t.Errorf("RoleSessionName = %q, want %q", got, ar.SessionName)
+	}
+	if creds.AccessKeyID != "assumed-access-key" {
+		t.Errorf("AccessKeyID = %q, want assumed-access-key", creds.AccessKeyID)
+	}
+}
+
+// fakeWebIdentityClient is a stscreds.AssumeRoleWithWebIdentityAPIClient
+// stand-in, analogous to fakeAssumeRoleClient.
+type fakeWebIdentityClient struct {
+	gotInput *sts.AssumeRoleWithWebIdentityInput
+}
+
+func (f *fakeWebIdentityClient) AssumeRoleWithWebIdentity(ctx context.Context, params *sts.AssumeRoleWithWebIdentityInput, optFns ...func(*sts.Options)) (*sts.AssumeRoleWithWebIdentityOutput, error) {
+	f.gotInput = params
+	return &sts.AssumeRoleWithWebIdentityOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String("web-identity-access-key"),
+			SecretAccessKey: aws.String("web-identity-secret-key"),
+			SessionToken:    aws.String("web-identity-session-token"),
+			Expiration:      aws.Time(time.Unix(0, 0)),
+		},
+	}, nil
+}
+
+// fakeIdentityToken is a stscreds.IdentityTokenRetriever stand-in returning
+// a canned token instead of reading one from disk.
+type fakeIdentityToken string
+
+func (t fakeIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+func TestWebIdentityOptionsAppliedToProvider(t *testing.T) {
+	fake := &fakeWebIdentityClient{}
+	wi := &WebIdentityConfig{
+		RoleARN:     "arn:aws:iam::123456789012:role/firmirror-writer",
+		SessionName: "firmirror-session",
+	}
+	roleARN, _ := resolveWebIdentityConfig(wi)
+	provider := stscreds.NewWebIdentityRoleProvider(fake, roleARN, fakeIdentityToken("jwt-token"), webIdentityOptions(wi))
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+
+	if fake.gotInput == nil {
+		t.Fatal("AssumeRoleWithWebIdentity was never called")
+	}
+	if got := aws.ToString(fake.gotInput.RoleArn); got != wi.RoleARN {
+		t.Errorf("RoleArn = %q, want %q", got, wi.RoleARN)
+	}
+	if got := aws.ToString(fake.gotInput.RoleSessionName); got != wi.SessionName {
+		t.Errorf("RoleSessionName = %q, want %q", got, wi.SessionName)
+	}
+	if got := aws.ToString(fake.gotInput.WebIdentityToken); got != "jwt-token" {
+		t.Errorf("WebIdentityToken = %q, want jwt-token", got)
+	}
+	if creds.AccessKeyID != "web-identity-access-key" {
+		t.Errorf("AccessKeyID = %q, want web-identity-access-key", creds.AccessKeyID)
+	}
+}
+
+func TestResolveWebIdentityConfigFallsBackToEnv(t *testing.T) {
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/env-role")
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/token")
+
+	roleARN, tokenFilePath := resolveWebIdentityConfig(&WebIdentityConfig{})
+	if roleARN != "arn:aws:iam::123456789012:role/env-role" {
+		t.Errorf("roleARN = %q, want value from AWS_ROLE_ARN", roleARN)
+	}
+	if tokenFilePath != "/var/run/secrets/token" {
+		t.Errorf("tokenFilePath = %q, want value from AWS_WEB_IDENTITY_TOKEN_FILE", tokenFilePath)
+	}
+
+	// Explicit values still take priority over the environment.
+	roleARN, tokenFilePath = resolveWebIdentityConfig(&WebIdentityConfig{
+		RoleARN:       "arn:aws:iam::123456789012:role/explicit-role",
+		TokenFilePath: "/explicit/token",
+	})
+	if roleARN != "arn:aws:iam::123456789012:role/explicit-role" {
+		t.Errorf("roleARN = %q, want explicit value", roleARN)
+	}
+	if tokenFilePath != "/explicit/token" {
+		t.Errorf("tokenFilePath = %q, want explicit value", tokenFilePath)
+	}
+}
+
+func TestS3StorageCredentialsAnonymous(t *testing.T) {
+	// The last WithCredentials option wins, so this overrides the Static
+	// credentials newTestS3Storage defaults to.
+	s, _ := newTestS3Storage(t, WithCredentials(CredentialsConfig{Anonymous: true}))
+	ctx := context.Background()
+
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader([]byte("firmware-bytes"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rc, err := s.Read(ctx, "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "firmware-bytes" {
+		t.Fatalf("got %q, want firmware-bytes", got)
+	}
+}
@@ -0,0 +1,359 @@
+package firmirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// fakeS3 is a minimal path-style S3 stand-in covering just enough of the API
+// surface (PutObject, GetObject, HeadObject, HeadBucket) to exercise
+// S3Storage's checksum verification against a real HTTP round trip.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+	meta    map[string]map[string]string
+	// headers records the request headers of the most recent PUT for each
+	// key, letting tests assert that write options (SSE, storage class,
+	// ACL, tagging) actually land on the wire.
+	headers map[string]http.Header
+}
+
+func newFakeS3(bucket string) (*httptest.Server, *fakeS3) {
+	f := &fakeS3{
+		bucket:  bucket,
+		objects: make(map[string][]byte),
+		meta:    make(map[string]map[string]string),
+		headers: make(map[string]http.Header),
+	}
+	return httptest.NewServer(http.HandlerFunc(f.handle)), f
+}
+
+// header returns the request headers captured for the most recent PUT of
+// key, or nil if none was seen.
+func (f *fakeS3) header(key string) http.Header {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.headers[key]
+}
+
+func (f *fakeS3) overwrite(key string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = data
+}
+
+// parseRange parses a "bytes=start-end" Range header (the only form the S3
+// download manager sends) into a half-open [start, end) byte range clamped
+// to size. ok is false when there was no Range header to honor.
+func parseRange(header string, size int) (start, end int, ok bool) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(header, prefix), "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	end = size
+	if parts[1] != "" {
+		endInclusive, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, false
+		}
+		end = endInclusive + 1
+	}
+	if end > size {
+		end = size
+	}
+	return start, end, true
+}
+
+func (f *fakeS3) handle(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/"+f.bucket+"/")
+
+	switch r.Method {
+	case http.MethodHead:
+		if r.URL.Path == "/"+f.bucket {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		f.mu.Lock()
+		data, ok := f.objects[key]
+		m := f.meta[key]
+		f.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range m {
+			w.Header().Set("x-amz-meta-"+k, v)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		newMeta := make(map[string]string)
+		for h := range r.Header {
+			if lh := strings.ToLower(h); strings.HasPrefix(lh, "x-amz-meta-") {
+				newMeta[strings.TrimPrefix(lh, "x-amz-meta-")] = r.Header.Get(h)
+			}
+		}
+		f.mu.Lock()
+		f.objects[key] = body
+		f.meta[key] = newMeta
+		f.headers[key] = r.Header.Clone()
+		f.mu.Unlock()
+		w.Header().Set("ETag", `"fake"`)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet:
+		f.mu.Lock()
+		data, ok := f.objects[key]
+		m := f.meta[key]
+		f.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range m {
+			w.Header().Set("x-amz-meta-"+k, v)
+		}
+
+		// manager.Downloader relies on Range/Content-Range to know when
+		// it has the whole object; without honoring Range here it loops
+		// forever requesting further chunks past the end of the object.
+		start, end, ranged := parseRange(r.Header.Get("Range"), len(data))
+		if ranged {
+			if start > len(data) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, len(data)))
+			w.Header().Set("Content-Length", strconv.Itoa(end-start))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(data[start:end])
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Storage(t *testing.T, opts ...Option) (*S3Storage, *fakeS3) {
+	t.Helper()
+	srv, fake := newFakeS3("test-bucket")
+	t.Cleanup(srv.Close)
+
+	allOpts := append([]Option{
+		WithCredentials(CredentialsConfig{Static: &StaticCredentials{
+			AccessKeyID:     "fake",
+			SecretAccessKey: "fake",
+		}}),
+	}, opts...)
+
+	s, err := NewS3Storage(context.Background(), "test-bucket", "", "us-east-1", srv.URL, allOpts...)
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+	return s, fake
+}
+
+func TestS3StorageWriteReadRoundTrip(t *testing.T) {
+	s, _ := newTestS3Storage(t)
+	ctx := context.Background()
+
+	want := []byte("firmware-bytes")
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader(want)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rc, err := s.Read(ctx, "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestS3StorageWriteHashesAndUploadsFromSameOffset is a regression test: Write
+// must hash and upload the same bytes even when the caller's reader isn't
+// positioned at offset 0, otherwise the checksum sidecar records a digest for
+// bytes that were never uploaded and every subsequent Read fails verification.
+func TestS3StorageWriteHashesAndUploadsFromSameOffset(t *testing.T) {
+	s, _ := newTestS3Storage(t)
+	ctx := context.Background()
+
+	r := bytes.NewReader([]byte("junk-prefixfirmware-bytes"))
+	if _, err := r.Seek(int64(len("junk-prefix")), io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	if err := s.Write(ctx, "fw.bin", r); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rc, err := s.Read(ctx, "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "firmware-bytes" {
+		t.Fatalf("got %q, want %q", got, "firmware-bytes")
+	}
+}
+
+func TestS3StorageWriteAppliesServerSideEncryption(t *testing.T) {
+	s, fake := newTestS3Storage(t, WithServerSideEncryption(types.ServerSideEncryptionAwsKms, "key-id"))
+	ctx := context.Background()
+
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader([]byte("firmware-bytes"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	h := fake.header(s.buildKey("fw.bin"))
+	if got := h.Get("X-Amz-Server-Side-Encryption"); got != "aws:kms" {
+		t.Fatalf("X-Amz-Server-Side-Encryption = %q, want aws:kms", got)
+	}
+	if got := h.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"); got != "key-id" {
+		t.Fatalf("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id = %q, want key-id", got)
+	}
+}
+
+func TestS3StorageWriteAppliesSSECustomerKey(t *testing.T) {
+	s, fake := newTestS3Storage(t, WithSSECustomerKey("AES256", "0123456789abcdef0123456789abcdef", "md5digest"))
+	ctx := context.Background()
+
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader([]byte("firmware-bytes"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	h := fake.header(s.buildKey("fw.bin"))
+	if got := h.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"); got != "AES256" {
+		t.Fatalf("X-Amz-Server-Side-Encryption-Customer-Algorithm = %q, want AES256", got)
+	}
+	if got := h.Get("X-Amz-Server-Side-Encryption-Customer-Key"); got != "0123456789abcdef0123456789abcdef" {
+		t.Fatalf("X-Amz-Server-Side-Encryption-Customer-Key = %q", got)
+	}
+	if got := h.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"); got != "md5digest" {
+		t.Fatalf("X-Amz-Server-Side-Encryption-Customer-Key-Md5 = %q", got)
+	}
+}
+
+func TestS3StorageWriteAppliesStorageClassACLAndTags(t *testing.T) {
+	s, fake := newTestS3Storage(t,
+		WithStorageClass(types.StorageClassStandardIa),
+		WithACL(types.ObjectCannedACLPrivate),
+		WithTags(map[string]string{"vendor": "acme"}),
+	)
+	ctx := context.Background()
+
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader([]byte("firmware-bytes"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	h := fake.header(s.buildKey("fw.bin"))
+	if got := h.Get("X-Amz-Storage-Class"); got != string(types.StorageClassStandardIa) {
+		t.Fatalf("X-Amz-Storage-Class = %q, want %q", got, types.StorageClassStandardIa)
+	}
+	if got := h.Get("X-Amz-Acl"); got != string(types.ObjectCannedACLPrivate) {
+		t.Fatalf("X-Amz-Acl = %q, want %q", got, types.ObjectCannedACLPrivate)
+	}
+	if got := h.Get("X-Amz-Tagging"); got != "vendor=acme" {
+		t.Fatalf("X-Amz-Tagging = %q, want vendor=acme", got)
+	}
+}
+
+func TestS3StoragePresignRead(t *testing.T) {
+	s, _ := newTestS3Storage(t)
+	ctx := context.Background()
+
+	urlStr, err := s.PresignRead(ctx, "fw.bin", 5*time.Minute,
+		WithPresignContentDisposition("attachment; filename=fw.bin"),
+	)
+	if err != nil {
+		t.Fatalf("PresignRead: %v", err)
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", urlStr, err)
+	}
+	if !strings.HasSuffix(u.Path, "/test-bucket/fw.bin") {
+		t.Fatalf("presigned URL path = %q, want suffix /test-bucket/fw.bin", u.Path)
+	}
+
+	q := u.Query()
+	if got := q.Get("X-Amz-Expires"); got != "300" {
+		t.Fatalf("X-Amz-Expires = %q, want 300", got)
+	}
+	if got := q.Get("response-content-disposition"); got != "attachment; filename=fw.bin" {
+		t.Fatalf("response-content-disposition = %q, want %q", got, "attachment; filename=fw.bin")
+	}
+}
+
+func TestS3StorageReadDetectsChecksumMismatch(t *testing.T) {
+	s, fake := newTestS3Storage(t)
+	ctx := context.Background()
+
+	if err := s.Write(ctx, "fw.bin", bytes.NewReader([]byte("original"))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Corrupt the stored bytes directly on the fake backend, leaving the
+	// checksum sidecar object untouched, to simulate bit-rot between write
+	// and read.
+	fake.overwrite(s.buildKey("fw.bin"), []byte("corrupted"))
+
+	rc, err := s.Read(ctx, "fw.bin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	defer rc.Close()
+
+	_, err = io.ReadAll(rc)
+	var mismatch *ChecksumMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected ChecksumMismatchError, got %v", err)
+	}
+}